@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+type Album struct {
+	Id                 string
+	Name               string
+	ArtistId           string
+	AlbumArtist        string
+	AlbumArtists       []ArtistRef
+	CoverArtId         string
+	Genre              string
+	Genres             []string
+	MusicBrainzAlbumId string
+	Year               int
+	SongCount          int
+	Duration           int
+	Starred            bool
+	PlayCount          int
+	PlayDate           time.Time
+	Rating             int
+	FolderId           string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+type AlbumRepository interface {
+	Get(id string) (*Album, error)
+	Exists(id string) (bool, error)
+	Put(al *Album) error
+
+	// FindByArtist paginates and sorts in the datastore, returning the matching page
+	// alongside the total number of albums for the artist.
+	FindByArtist(artistId string, qo QueryOptions) ([]Album, int, error)
+
+	// Discovery queries backing engine.AlbumLister's getAlbumList2-style kinds. Genre and the
+	// year range are carried on ListOptions itself rather than as separate arguments.
+	FindNewest(options ListOptions) ([]Album, error)
+	FindRecentlyPlayed(options ListOptions) ([]Album, error)
+	FindFrequentlyPlayed(options ListOptions) ([]Album, error)
+	FindTopRated(options ListOptions) ([]Album, error)
+	FindStarred(options ListOptions) ([]Album, error)
+	FindByYearRange(options ListOptions) ([]Album, error)
+	FindByGenre(options ListOptions) ([]Album, error)
+	FindRandom(options ListOptions) ([]Album, error)
+}
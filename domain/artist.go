@@ -0,0 +1,13 @@
+package domain
+
+type Artist struct {
+	Id         string
+	Name       string
+	AlbumCount int
+}
+
+type ArtistRepository interface {
+	Get(id string) (*Artist, error)
+	Exists(id string) (bool, error)
+	Put(a *Artist) error
+}
@@ -0,0 +1,13 @@
+package domain
+
+// ArtistIndex groups Artist entries under an index letter, for the folder-based Indexes() call.
+type ArtistIndex struct {
+	Id      string
+	Artists []Artist
+}
+
+type ArtistIndexes []ArtistIndex
+
+type ArtistIndexRepository interface {
+	GetAll() (ArtistIndexes, error)
+}
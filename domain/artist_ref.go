@@ -0,0 +1,8 @@
+package domain
+
+// ArtistRef identifies a single credited artist, used where a track or album can have more
+// than one (e.g. collaborations, compilation album artists).
+type ArtistRef struct {
+	Id   string
+	Name string
+}
@@ -0,0 +1,13 @@
+package domain
+
+// ListOptions paginates a discovery-style listing (AlbumRepository.FindNewest and friends)
+// and optionally scopes it to a single media folder and, for FindByGenre/FindByYearRange,
+// the genre/year range to match.
+type ListOptions struct {
+	Offset   int
+	Size     int
+	FolderId string
+	Genre    string
+	FromYear int
+	ToYear   int
+}
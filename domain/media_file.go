@@ -0,0 +1,60 @@
+package domain
+
+import "time"
+
+type MediaFile struct {
+	Id                 string
+	Title              string
+	Album              string
+	AlbumId            string
+	Artist             string
+	ArtistId           string
+	Artists            []ArtistRef
+	AlbumArtists       []ArtistRef
+	Genre              string
+	Genres             []string
+	MusicBrainzTrackId string
+	DiscNumber         int
+	Comment            string
+	Bpm                int
+	RGTrackGain        float32
+	RGTrackPeak        float32
+	RGAlbumGain        float32
+	RGAlbumPeak        float32
+	Year               int
+	TrackNumber        int
+	Duration           int
+	Size               string
+	Suffix             string
+	BitRate            int
+	HasCoverArt        bool
+	Starred            bool
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+var contentTypesBySuffix = map[string]string{
+	"mp3":  "audio/mpeg",
+	"ogg":  "audio/ogg",
+	"oga":  "audio/ogg",
+	"m4a":  "audio/mp4",
+	"flac": "audio/flac",
+	"wav":  "audio/x-wav",
+	"wma":  "audio/x-ms-wma",
+}
+
+func (mf MediaFile) ContentType() string {
+	if ct, ok := contentTypesBySuffix[mf.Suffix]; ok {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+type MediaFileRepository interface {
+	Get(id string) (*MediaFile, error)
+	Put(mf *MediaFile) error
+
+	// FindByAlbum paginates and sorts in the datastore, returning the matching page
+	// alongside the total number of tracks in the album.
+	FindByAlbum(albumId string, qo QueryOptions) ([]MediaFile, int, error)
+}
@@ -0,0 +1,7 @@
+package domain
+
+type PropertyRepository interface {
+	Put(id string, value string) error
+	Get(id string) (string, error)
+	DefaultGet(id string, defaultValue string) (string, error)
+}
@@ -0,0 +1,18 @@
+package domain
+
+// SortBy values accepted in QueryOptions.SortBy.
+const (
+	SortByName  = "name"
+	SortByYear  = "year"
+	SortByAdded = "added"
+	SortRandom  = "random"
+)
+
+// QueryOptions paginates and sorts a repository listing. A zero-value QueryOptions means
+// "no pagination, default order", so existing callers keep working unchanged.
+type QueryOptions struct {
+	Offset int
+	Limit  int
+	SortBy string
+	Desc   bool
+}
@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/deluan/gosonic/domain"
+)
+
+// Kind values accepted by AlbumLister.List, mirroring Subsonic's getAlbumList2 ltype parameter.
+const (
+	AlbumListNewest         = "newest"
+	AlbumListRecentlyPlayed = "recently_played"
+	AlbumListFrequent       = "frequent"
+	AlbumListHighest        = "highest"
+	AlbumListStarred        = "starred"
+	AlbumListByYear         = "byYear"
+	AlbumListByGenre        = "byGenre"
+	AlbumListRandom         = "random"
+)
+
+// ListOptions paginates a List call and optionally scopes it to a single media folder and,
+// for AlbumListByGenre/AlbumListByYear, the genre/year range to match. It's the same type
+// domain.AlbumRepository's discovery queries take, so List just forwards it untouched.
+type ListOptions = domain.ListOptions
+
+type AlbumLister interface {
+	List(kind string, options ListOptions) ([]AlbumInfo, error)
+}
+
+func NewAlbumLister(alr domain.AlbumRepository) AlbumLister {
+	return albumLister{alr}
+}
+
+type albumLister struct {
+	albumRepo domain.AlbumRepository
+}
+
+func (l albumLister) List(kind string, options ListOptions) ([]AlbumInfo, error) {
+	var albums []domain.Album
+	var err error
+
+	switch kind {
+	case AlbumListNewest:
+		albums, err = l.albumRepo.FindNewest(options)
+	case AlbumListRecentlyPlayed:
+		albums, err = l.albumRepo.FindRecentlyPlayed(options)
+	case AlbumListFrequent:
+		albums, err = l.albumRepo.FindFrequentlyPlayed(options)
+	case AlbumListHighest:
+		albums, err = l.albumRepo.FindTopRated(options)
+	case AlbumListStarred:
+		albums, err = l.albumRepo.FindStarred(options)
+	case AlbumListByYear:
+		albums, err = l.albumRepo.FindByYearRange(options)
+	case AlbumListByGenre:
+		albums, err = l.albumRepo.FindByGenre(options)
+	case AlbumListRandom:
+		albums, err = l.albumRepo.FindRandom(options)
+	default:
+		return nil, fmt.Errorf("Invalid list kind: %s", kind)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving %s album list: %v", kind, err)
+	}
+
+	infos := make([]AlbumInfo, len(albums))
+	for i, al := range albums {
+		infos[i] = newAlbumInfo(&al)
+	}
+	return infos, nil
+}
@@ -19,7 +19,12 @@ var (
 type Browser interface {
 	MediaFolders() (domain.MediaFolders, error)
 	Indexes(ifModifiedSince time.Time) (domain.ArtistIndexes, time.Time, error)
-	Directory(id string) (*DirectoryInfo, error)
+	Directory(id string, qo QueryOptions) (*DirectoryInfo, error)
+
+	ArtistsIndex(ifModifiedSince time.Time) ([]ArtistIndexID3, time.Time, error)
+	Artist(id string) (*ArtistInfo, error)
+	Album(id string) (*AlbumInfo, error)
+	Song(id string) (*SongInfo, error)
 }
 
 func NewBrowser(pr domain.PropertyRepository, fr domain.MediaFolderRepository, ir domain.ArtistIndexRepository,
@@ -57,48 +62,146 @@ func (b browser) Indexes(ifModifiedSince time.Time) (domain.ArtistIndexes, time.
 	return domain.ArtistIndexes{}, lastModified, nil
 }
 
+// ArtistRef identifies a single credited artist, used where a track or album can have more
+// than one (e.g. collaborations, compilation album artists).
+type ArtistRef struct {
+	Id   string
+	Name string
+}
+
 type Child struct {
-	Id          string
-	Title       string
-	IsDir       bool
-	Parent      string
-	Album       string
-	Year        int
-	Artist      string
-	Genre       string
-	CoverArt    string
-	Starred     time.Time
-	Track       int
-	Duration    int
-	Size        string
-	Suffix      string
-	BitRate     int
-	ContentType string
+	Id            string
+	Title         string
+	IsDir         bool
+	Parent        string
+	Album         string
+	Year          int
+	Artist        string
+	Genre         string
+	CoverArt      string
+	Starred       time.Time
+	Track         int
+	Duration      int
+	Size          string
+	Suffix        string
+	BitRate       int
+	ContentType   string
+	Artists       []ArtistRef
+	AlbumArtists  []ArtistRef
+	Genres        []string
+	MusicBrainzId string
+	DiscNumber    int
+	Comment       string
+	Bpm           int
+	TrackGain     float32
+	TrackPeak     float32
+	AlbumGain     float32
+	AlbumPeak     float32
 }
 
 type DirectoryInfo struct {
-	Id       string
-	Name     string
-	Children []Child
+	Id         string
+	Name       string
+	Children   []Child
+	TotalCount int
+}
+
+// SortBy values accepted in QueryOptions.SortBy.
+const (
+	SortByName  = domain.SortByName
+	SortByYear  = domain.SortByYear
+	SortByAdded = domain.SortByAdded
+	SortRandom  = domain.SortRandom
+)
+
+// QueryOptions paginates and sorts a listing. It's the same type the repositories use, so
+// pagination happens in the datastore rather than in memory. A zero-value QueryOptions means
+// "no pagination, default order", so existing callers keep working unchanged.
+type QueryOptions = domain.QueryOptions
+
+// ArtistInfo, AlbumInfo and SongInfo are the ID3 counterparts of Child/DirectoryInfo: they
+// address artists, albums and songs by their real ids, not by the folder id they live under.
+
+type ArtistInfo struct {
+	Id         string
+	Name       string
+	AlbumCount int
+	CoverArt   string
+	Starred    time.Time
+	Albums     []AlbumInfo
 }
 
-func (c browser) Directory(id string) (*DirectoryInfo, error) {
+type AlbumInfo struct {
+	Id            string
+	Name          string
+	ArtistId      string
+	Artist        string
+	CoverArt      string
+	SongCount     int
+	Duration      int
+	Created       time.Time
+	Year          int
+	Genre         string
+	Starred       time.Time
+	Songs         []SongInfo
+	AlbumArtists  []ArtistRef
+	Genres        []string
+	MusicBrainzId string
+}
+
+type SongInfo struct {
+	Id            string
+	Title         string
+	Album         string
+	AlbumId       string
+	Artist        string
+	ArtistId      string
+	Track         int
+	Year          int
+	Genre         string
+	CoverArt      string
+	Size          string
+	Suffix        string
+	BitRate       int
+	ContentType   string
+	Duration      int
+	Starred       time.Time
+	Artists       []ArtistRef
+	Genres        []string
+	MusicBrainzId string
+	DiscNumber    int
+	Comment       string
+	Bpm           int
+	TrackGain     float32
+	TrackPeak     float32
+	AlbumGain     float32
+	AlbumPeak     float32
+}
+
+// ArtistIndexID3 groups ArtistInfo entries under an index letter, the same way
+// domain.ArtistIndex groups domain.Artist entries for the folder-based Indexes() call.
+type ArtistIndexID3 struct {
+	Id      string
+	Artists []ArtistInfo
+}
+
+func (c browser) Directory(id string, qo QueryOptions) (*DirectoryInfo, error) {
 	var dir *DirectoryInfo
 	switch {
 	case c.isArtist(id):
 		beego.Info("Found Artist with id", id)
-		a, albums, err := c.retrieveArtist(id)
+		a, albums, total, err := c.retrieveArtist(id, qo)
 		if err != nil {
 			return nil, err
 		}
-		dir = c.buildArtistDir(a, albums)
+		dir = c.buildArtistDir(a, albums, total)
 	case c.isAlbum(id):
 		beego.Info("Found Album with id", id)
-		al, tracks, err := c.retrieveAlbum(id)
+		al, tracks, total, err := c.retrieveAlbum(id, qo)
 		if err != nil {
 			return nil, err
 		}
-		dir = c.buildAlbumDir(al, tracks)
+		dir = c.buildAlbumDir(al, tracks, total)
 	default:
 		beego.Info("Id", id, "not found")
 		return nil, DataNotFound
@@ -107,8 +210,8 @@ func (c browser) Directory(id string) (*DirectoryInfo, error) {
 	return dir, nil
 }
 
-func (c browser) buildArtistDir(a *domain.Artist, albums []domain.Album) *DirectoryInfo {
-	dir := &DirectoryInfo{Id: a.Id, Name: a.Name}
+func (c browser) buildArtistDir(a *domain.Artist, albums []domain.Album, total int) *DirectoryInfo {
+	dir := &DirectoryInfo{Id: a.Id, Name: a.Name, TotalCount: total}
 
 	dir.Children = make([]Child, len(albums))
 	for i, al := range albums {
@@ -121,6 +224,9 @@ func (c browser) buildArtistDir(a *domain.Artist, albums []domain.Album) *Direct
 		dir.Children[i].Artist = al.AlbumArtist
 		dir.Children[i].Genre = al.Genre
 		dir.Children[i].CoverArt = al.CoverArtId
+		dir.Children[i].AlbumArtists = mapArtistRefs(al.AlbumArtists)
+		dir.Children[i].Genres = al.Genres
+		dir.Children[i].MusicBrainzId = al.MusicBrainzAlbumId
 		if al.Starred {
 			dir.Children[i].Starred = al.UpdatedAt
 		}
@@ -129,8 +235,8 @@ func (c browser) buildArtistDir(a *domain.Artist, albums []domain.Album) *Direct
 	return dir
 }
 
-func (c browser) buildAlbumDir(al *domain.Album, tracks []domain.MediaFile) *DirectoryInfo {
-	dir := &DirectoryInfo{Id: al.Id, Name: al.Name}
+func (c browser) buildAlbumDir(al *domain.Album, tracks []domain.MediaFile, total int) *DirectoryInfo {
+	dir := &DirectoryInfo{Id: al.Id, Name: al.Name, TotalCount: total}
 
 	dir.Children = make([]Child, len(tracks))
 	for i, mf := range tracks {
@@ -147,6 +253,17 @@ func (c browser) buildAlbumDir(al *domain.Album, tracks []domain.MediaFile) *Dir
 		dir.Children[i].Size = mf.Size
 		dir.Children[i].Suffix = mf.Suffix
 		dir.Children[i].BitRate = mf.BitRate
+		dir.Children[i].Artists = mapArtistRefs(mf.Artists)
+		dir.Children[i].AlbumArtists = mapArtistRefs(mf.AlbumArtists)
+		dir.Children[i].Genres = mf.Genres
+		dir.Children[i].MusicBrainzId = mf.MusicBrainzTrackId
+		dir.Children[i].DiscNumber = mf.DiscNumber
+		dir.Children[i].Comment = mf.Comment
+		dir.Children[i].Bpm = mf.Bpm
+		dir.Children[i].TrackGain = mf.RGTrackGain
+		dir.Children[i].TrackPeak = mf.RGTrackPeak
+		dir.Children[i].AlbumGain = mf.RGAlbumGain
+		dir.Children[i].AlbumPeak = mf.RGAlbumPeak
 		if mf.Starred {
 			dir.Children[i].Starred = mf.UpdatedAt
 		}
@@ -158,6 +275,156 @@ func (c browser) buildAlbumDir(al *domain.Album, tracks []domain.MediaFile) *Dir
 	return dir
 }
 
+func (c browser) ArtistsIndex(ifModifiedSince time.Time) ([]ArtistIndexID3, time.Time, error) {
+	l, err := c.propRepo.DefaultGet(consts.LastScan, "-1")
+	ms, _ := strconv.ParseInt(l, 10, 64)
+	lastModified := utils.ToTime(ms)
+
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("Error retrieving LastScan property: %v", err)
+	}
+
+	if !lastModified.After(ifModifiedSince) {
+		return nil, lastModified, nil
+	}
+
+	folderIndexes, err := c.indexRepo.GetAll()
+	if err != nil {
+		return nil, lastModified, err
+	}
+
+	indexes := make([]ArtistIndexID3, len(folderIndexes))
+	for i, fi := range folderIndexes {
+		artists := make([]ArtistInfo, len(fi.Artists))
+		for j, a := range fi.Artists {
+			artists[j] = ArtistInfo{Id: a.Id, Name: a.Name, AlbumCount: a.AlbumCount}
+		}
+		indexes[i] = ArtistIndexID3{Id: fi.Id, Artists: artists}
+	}
+
+	return indexes, lastModified, nil
+}
+
+func (c browser) Artist(id string) (*ArtistInfo, error) {
+	a, albums, _, err := c.retrieveArtist(id, QueryOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	info := c.buildArtistInfo(a, albums)
+	return &info, nil
+}
+
+func (c browser) Album(id string) (*AlbumInfo, error) {
+	al, tracks, _, err := c.retrieveAlbum(id, QueryOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	info := c.buildAlbumInfo(al)
+	info.Songs = make([]SongInfo, len(tracks))
+	for i, mf := range tracks {
+		info.Songs[i] = c.buildSongInfo(&mf)
+	}
+	return &info, nil
+}
+
+func (c browser) Song(id string) (*SongInfo, error) {
+	mf, err := c.mfileRepo.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading Song %s from DB: %v", id, err)
+	}
+
+	info := c.buildSongInfo(mf)
+	return &info, nil
+}
+
+func (c browser) buildArtistInfo(a *domain.Artist, albums []domain.Album) ArtistInfo {
+	info := ArtistInfo{Id: a.Id, Name: a.Name, AlbumCount: len(albums)}
+	info.Albums = make([]AlbumInfo, len(albums))
+	for i, al := range albums {
+		info.Albums[i] = newAlbumInfo(&al)
+	}
+	return info
+}
+
+func (c browser) buildAlbumInfo(al *domain.Album) AlbumInfo {
+	return newAlbumInfo(al)
+}
+
+// newAlbumInfo converts a domain.Album into an AlbumInfo. It is a package-level function,
+// rather than a browser method, so it can also be used by AlbumLister.
+func newAlbumInfo(al *domain.Album) AlbumInfo {
+	info := AlbumInfo{
+		Id:            al.Id,
+		Name:          al.Name,
+		ArtistId:      al.ArtistId,
+		Artist:        al.AlbumArtist,
+		CoverArt:      al.CoverArtId,
+		SongCount:     al.SongCount,
+		Duration:      al.Duration,
+		Created:       al.CreatedAt,
+		Year:          al.Year,
+		Genre:         al.Genre,
+		AlbumArtists:  mapArtistRefs(al.AlbumArtists),
+		Genres:        al.Genres,
+		MusicBrainzId: al.MusicBrainzAlbumId,
+	}
+	if al.Starred {
+		info.Starred = al.UpdatedAt
+	}
+	return info
+}
+
+func (c browser) buildSongInfo(mf *domain.MediaFile) SongInfo {
+	info := SongInfo{
+		Id:            mf.Id,
+		Title:         mf.Title,
+		Album:         mf.Album,
+		AlbumId:       mf.AlbumId,
+		Artist:        mf.Artist,
+		ArtistId:      mf.ArtistId,
+		Track:         mf.TrackNumber,
+		Year:          mf.Year,
+		Genre:         mf.Genre,
+		Size:          mf.Size,
+		Suffix:        mf.Suffix,
+		BitRate:       mf.BitRate,
+		ContentType:   mf.ContentType(),
+		Duration:      mf.Duration,
+		Artists:       mapArtistRefs(mf.Artists),
+		Genres:        mf.Genres,
+		MusicBrainzId: mf.MusicBrainzTrackId,
+		DiscNumber:    mf.DiscNumber,
+		Comment:       mf.Comment,
+		Bpm:           mf.Bpm,
+		TrackGain:     mf.RGTrackGain,
+		TrackPeak:     mf.RGTrackPeak,
+		AlbumGain:     mf.RGAlbumGain,
+		AlbumPeak:     mf.RGAlbumPeak,
+	}
+	if mf.HasCoverArt {
+		info.CoverArt = mf.Id
+	}
+	if mf.Starred {
+		info.Starred = mf.UpdatedAt
+	}
+	return info
+}
+
+// mapArtistRefs converts domain artist references (used by multi-artist tracks and albums)
+// into the ArtistRef shape exposed by the engine.
+func mapArtistRefs(refs []domain.ArtistRef) []ArtistRef {
+	if refs == nil {
+		return nil
+	}
+	result := make([]ArtistRef, len(refs))
+	for i, r := range refs {
+		result[i] = ArtistRef{Id: r.Id, Name: r.Name}
+	}
+	return result
+}
+
 func (c browser) isArtist(id string) bool {
 	found, err := c.artistRepo.Exists(id)
 	if err != nil {
@@ -176,27 +443,27 @@ func (c browser) isAlbum(id string) bool {
 	return found
 }
 
-func (c browser) retrieveArtist(id string) (a *domain.Artist, as []domain.Album, err error) {
+func (c browser) retrieveArtist(id string, qo QueryOptions) (a *domain.Artist, as []domain.Album, total int, err error) {
 	a, err = c.artistRepo.Get(id)
 	if err != nil {
 		err = fmt.Errorf("Error reading Artist %s from DB: %v", id, err)
 		return
 	}
 
-	if as, err = c.albumRepo.FindByArtist(id); err != nil {
+	if as, total, err = c.albumRepo.FindByArtist(id, qo); err != nil {
 		err = fmt.Errorf("Error reading %s's albums from DB: %v", a.Name, err)
 	}
 	return
 }
 
-func (c browser) retrieveAlbum(id string) (al *domain.Album, mfs []domain.MediaFile, err error) {
+func (c browser) retrieveAlbum(id string, qo QueryOptions) (al *domain.Album, mfs []domain.MediaFile, total int, err error) {
 	al, err = c.albumRepo.Get(id)
 	if err != nil {
 		err = fmt.Errorf("Error reading Album %s from DB: %v", id, err)
 		return
 	}
 
-	if mfs, err = c.mfileRepo.FindByAlbum(id); err != nil {
+	if mfs, total, err = c.mfileRepo.FindByAlbum(id, qo); err != nil {
 		err = fmt.Errorf("Error reading %s's tracks from DB: %v", al.Name, err)
 	}
 	return
@@ -0,0 +1,20 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/deluan/gosonic/domain"
+)
+
+func TestNewAlbumInfoCarriesSongCountAndDuration(t *testing.T) {
+	al := &domain.Album{Id: "al-1", Name: "Album X", SongCount: 2, Duration: 380}
+
+	info := newAlbumInfo(al)
+
+	if info.SongCount != 2 {
+		t.Errorf("AlbumInfo.SongCount = %d, want 2", info.SongCount)
+	}
+	if info.Duration != 380 {
+		t.Errorf("AlbumInfo.Duration = %d, want 380", info.Duration)
+	}
+}
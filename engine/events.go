@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego"
+)
+
+// Topic identifies the kind of change an Event carries.
+type Topic string
+
+const (
+	TopicDirectoryInvalidated Topic = "directory_invalidated"
+	TopicArtistAdded          Topic = "artist_added"
+	TopicArtistUpdated        Topic = "artist_updated"
+	TopicArtistRemoved        Topic = "artist_removed"
+	TopicAlbumAdded           Topic = "album_added"
+	TopicAlbumUpdated         Topic = "album_updated"
+	TopicAlbumRemoved         Topic = "album_removed"
+	TopicTrackAdded           Topic = "track_added"
+	TopicTrackUpdated         Topic = "track_updated"
+	TopicTrackRemoved         Topic = "track_removed"
+)
+
+// Event is published on an Events bus whenever the library changes. Id is the affected
+// entity's id, empty for bus-wide events such as TopicDirectoryInvalidated.
+type Event struct {
+	Topic     Topic
+	Id        string
+	Timestamp time.Time
+}
+
+// Events is a simple pub/sub bus. The scanner, which is where library changes are actually
+// detected, publishes to it as it adds and updates artists/albums/tracks, and once
+// more for the scan as a whole via TopicDirectoryInvalidated. The scanner does not detect
+// removed files, so nothing publishes the *Removed topics. Subscribers (a future
+// WebSocket/SSE endpoint, cover art and search index caches) use it to invalidate precisely
+// instead of on wall-clock timers.
+type Events interface {
+	Subscribe(topic Topic) <-chan Event
+	Publish(e Event)
+}
+
+func NewEvents() Events {
+	return &eventBus{subscribers: map[Topic][]chan Event{}}
+}
+
+type eventBus struct {
+	mu          sync.RWMutex
+	subscribers map[Topic][]chan Event
+}
+
+func (b *eventBus) Subscribe(topic Topic) <-chan Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan Event, 16)
+	b.subscribers[topic] = append(b.subscribers[topic], ch)
+	return ch
+}
+
+func (b *eventBus) Publish(e Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, ch := range b.subscribers[e.Topic] {
+		select {
+		case ch <- e:
+		default:
+			beego.Info("Dropping event, subscriber channel is full", e.Topic)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package engine
+
+import "testing"
+
+func TestEventsSubscribeAndPublish(t *testing.T) {
+	ev := NewEvents()
+	ch := ev.Subscribe(TopicAlbumAdded)
+
+	ev.Publish(Event{Topic: TopicAlbumAdded, Id: "al-1"})
+
+	select {
+	case e := <-ch:
+		if e.Id != "al-1" {
+			t.Errorf("got event id %q, want %q", e.Id, "al-1")
+		}
+	default:
+		t.Fatal("expected an event to be delivered to the subscriber")
+	}
+}
+
+func TestEventsOnlyDeliversToMatchingTopic(t *testing.T) {
+	ev := NewEvents()
+	ch := ev.Subscribe(TopicAlbumAdded)
+
+	ev.Publish(Event{Topic: TopicTrackAdded, Id: "tr-1"})
+
+	select {
+	case e := <-ch:
+		t.Errorf("unexpected event delivered for unsubscribed topic: %+v", e)
+	default:
+	}
+}
+
+func TestEventsPublishDoesNotBlockOnFullSubscriber(t *testing.T) {
+	ev := NewEvents()
+	ch := ev.Subscribe(TopicTrackAdded)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < cap(ch)+5; i++ {
+			ev.Publish(Event{Topic: TopicTrackAdded, Id: "flood"})
+		}
+		close(done)
+	}()
+
+	<-done // Publish must return even once the subscriber's channel buffer is full.
+
+	// Drain so the test doesn't leak an unread channel.
+	for {
+		select {
+		case <-ch:
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"github.com/astaxie/beego/orm"
+	"github.com/deluan/gosonic/domain"
+)
+
+type albumRepository struct {
+	ormer orm.Ormer
+}
+
+func NewAlbumRepository() domain.AlbumRepository {
+	return albumRepository{orm.NewOrm()}
+}
+
+func (r albumRepository) Get(id string) (*domain.Album, error) {
+	al := &domain.Album{Id: id}
+	if err := r.ormer.Read(al); err != nil {
+		return nil, err
+	}
+	return al, nil
+}
+
+func (r albumRepository) Exists(id string) (bool, error) {
+	return r.ormer.QueryTable(&domain.Album{}).Filter("Id", id).Exist(), nil
+}
+
+func (r albumRepository) Put(al *domain.Album) error {
+	_, err := r.ormer.InsertOrUpdate(al)
+	return err
+}
+
+func (r albumRepository) FindByArtist(artistId string, qo domain.QueryOptions) ([]domain.Album, int, error) {
+	qs := r.ormer.QueryTable(&domain.Album{}).Filter("ArtistId", artistId)
+
+	total, err := qs.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var albums []domain.Album
+	if _, err := applyQueryOptions(qs, qo).All(&albums); err != nil {
+		return nil, 0, err
+	}
+	return albums, int(total), nil
+}
+
+func (r albumRepository) FindNewest(options domain.ListOptions) ([]domain.Album, error) {
+	return r.findList(r.scopeToFolder(options).OrderBy("-CreatedAt"), options)
+}
+
+func (r albumRepository) FindRecentlyPlayed(options domain.ListOptions) ([]domain.Album, error) {
+	return r.findList(r.scopeToFolder(options).Filter("PlayDate__isnull", false).OrderBy("-PlayDate"), options)
+}
+
+func (r albumRepository) FindFrequentlyPlayed(options domain.ListOptions) ([]domain.Album, error) {
+	return r.findList(r.scopeToFolder(options).Filter("PlayCount__gt", 0).OrderBy("-PlayCount"), options)
+}
+
+func (r albumRepository) FindTopRated(options domain.ListOptions) ([]domain.Album, error) {
+	return r.findList(r.scopeToFolder(options).Filter("Rating__gt", 0).OrderBy("-Rating"), options)
+}
+
+func (r albumRepository) FindStarred(options domain.ListOptions) ([]domain.Album, error) {
+	return r.findList(r.scopeToFolder(options).Filter("Starred", true).OrderBy("-UpdatedAt"), options)
+}
+
+func (r albumRepository) FindByYearRange(options domain.ListOptions) ([]domain.Album, error) {
+	qs := r.scopeToFolder(options).Filter("Year__gte", options.FromYear)
+	if options.ToYear > 0 {
+		qs = qs.Filter("Year__lte", options.ToYear)
+	}
+	return r.findList(qs.OrderBy("Year"), options)
+}
+
+func (r albumRepository) FindByGenre(options domain.ListOptions) ([]domain.Album, error) {
+	return r.findList(r.scopeToFolder(options).Filter("Genre", options.Genre).OrderBy("Name"), options)
+}
+
+func (r albumRepository) FindRandom(options domain.ListOptions) ([]domain.Album, error) {
+	var albums []domain.Album
+	if _, err := r.scopeToFolder(options).All(&albums); err != nil {
+		return nil, err
+	}
+	return randomPage(albums, options.Offset, options.Size), nil
+}
+
+func (r albumRepository) scopeToFolder(options domain.ListOptions) orm.QuerySeter {
+	qs := r.ormer.QueryTable(&domain.Album{})
+	if options.FolderId != "" {
+		qs = qs.Filter("FolderId", options.FolderId)
+	}
+	return qs
+}
+
+func (r albumRepository) findList(qs orm.QuerySeter, options domain.ListOptions) ([]domain.Album, error) {
+	qs = qs.Limit(options.Size, options.Offset)
+
+	var albums []domain.Album
+	if _, err := qs.All(&albums); err != nil {
+		return nil, err
+	}
+	return albums, nil
+}
@@ -0,0 +1,42 @@
+package persistence
+
+import (
+	"github.com/astaxie/beego/orm"
+	"github.com/deluan/gosonic/domain"
+)
+
+type mediaFileRepository struct {
+	ormer orm.Ormer
+}
+
+func NewMediaFileRepository() domain.MediaFileRepository {
+	return mediaFileRepository{orm.NewOrm()}
+}
+
+func (r mediaFileRepository) Get(id string) (*domain.MediaFile, error) {
+	mf := &domain.MediaFile{Id: id}
+	if err := r.ormer.Read(mf); err != nil {
+		return nil, err
+	}
+	return mf, nil
+}
+
+func (r mediaFileRepository) Put(mf *domain.MediaFile) error {
+	_, err := r.ormer.InsertOrUpdate(mf)
+	return err
+}
+
+func (r mediaFileRepository) FindByAlbum(albumId string, qo domain.QueryOptions) ([]domain.MediaFile, int, error) {
+	qs := r.ormer.QueryTable(&domain.MediaFile{}).Filter("AlbumId", albumId)
+
+	total, err := qs.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var tracks []domain.MediaFile
+	if _, err := applyQueryOptions(qs, qo).All(&tracks); err != nil {
+		return nil, 0, err
+	}
+	return tracks, int(total), nil
+}
@@ -0,0 +1,10 @@
+package persistence
+
+import (
+	"github.com/astaxie/beego/orm"
+	"github.com/deluan/gosonic/domain"
+)
+
+func init() {
+	orm.RegisterModel(new(domain.Album), new(domain.MediaFile))
+}
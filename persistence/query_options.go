@@ -0,0 +1,86 @@
+package persistence
+
+import (
+	"math/rand"
+
+	"github.com/astaxie/beego/orm"
+	"github.com/deluan/gosonic/domain"
+)
+
+var sortColumns = map[string]string{
+	domain.SortByName:  "Name",
+	domain.SortByYear:  "Year",
+	domain.SortByAdded: "CreatedAt",
+}
+
+// applyQueryOptions applies qo's sort order and pagination to qs, so paging happens in the
+// datastore instead of in memory. Call it after qs.Count(), since Limit/Offset affect it.
+func applyQueryOptions(qs orm.QuerySeter, qo domain.QueryOptions) orm.QuerySeter {
+	qs = applySort(qs, qo)
+
+	if limit, offset, paginate := pagingBounds(qo); paginate {
+		qs = qs.Limit(limit, offset)
+	}
+
+	return qs
+}
+
+func applySort(qs orm.QuerySeter, qo domain.QueryOptions) orm.QuerySeter {
+	col, ok := sortColumn(qo)
+	if !ok {
+		return qs
+	}
+	return qs.OrderBy(col)
+}
+
+// sortColumn translates qo.SortBy into the column expression applySort should pass to
+// OrderBy, kept separate so the translation can be unit tested without an orm.QuerySeter.
+//
+// domain.SortRandom has no translation here: OrderBy validates its argument against the
+// model's registered field names, not arbitrary SQL, so there's no portable "ORDER BY RAND()"
+// to hand it, and shuffling in Go would mean loading every matching row first, defeating the
+// point of pushing pagination into the datastore. A SortRandom request therefore falls back to
+// the datastore's default order, same as any other unrecognized SortBy; domain.AlbumRepository
+// .FindRandom is where actual randomization happens instead, by loading every album in scope
+// and paging it in memory with randomPage.
+func sortColumn(qo domain.QueryOptions) (col string, ok bool) {
+	col, ok = sortColumns[qo.SortBy]
+	if !ok {
+		return "", false
+	}
+	if qo.Desc {
+		col = "-" + col
+	}
+	return col, true
+}
+
+// pagingBounds translates a QueryOptions into the limit/offset to pass to the datastore.
+// A zero-value QueryOptions (Limit <= 0) means "no pagination": paginate is false and the
+// caller should return every row, matching the compatibility guarantee QueryOptions documents.
+func pagingBounds(qo domain.QueryOptions) (limit, offset int, paginate bool) {
+	if qo.Limit <= 0 {
+		return 0, 0, false
+	}
+	return qo.Limit, qo.Offset, true
+}
+
+// randomPage returns a random, paginated sample of albums, for FindRandom (see sortColumn's
+// comment for why the datastore can't do this for us). albums is every album FindRandom loaded:
+// those of one media folder when ListOptions.FolderId is set, otherwise the whole catalog.
+// offset/size follow the same "size <= 0 means no limit" convention as QueryOptions.
+func randomPage(albums []domain.Album, offset, size int) []domain.Album {
+	shuffled := make([]domain.Album, len(albums))
+	copy(shuffled, albums)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	if offset > len(shuffled) {
+		offset = len(shuffled)
+	}
+	end := len(shuffled)
+	if size > 0 && offset+size < end {
+		end = offset + size
+	}
+	return shuffled[offset:end]
+}
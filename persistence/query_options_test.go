@@ -0,0 +1,74 @@
+package persistence
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/deluan/gosonic/domain"
+)
+
+func TestPagingBounds(t *testing.T) {
+	if _, _, paginate := pagingBounds(domain.QueryOptions{}); paginate {
+		t.Error("zero-value QueryOptions must not paginate")
+	}
+
+	if _, _, paginate := pagingBounds(domain.QueryOptions{Offset: 10}); paginate {
+		t.Error("an Offset with no Limit must not paginate")
+	}
+
+	limit, offset, paginate := pagingBounds(domain.QueryOptions{Offset: 50, Limit: 25})
+	if !paginate {
+		t.Fatal("a positive Limit must paginate")
+	}
+	if limit != 25 || offset != 50 {
+		t.Errorf("got limit=%d offset=%d, want limit=25 offset=50", limit, offset)
+	}
+}
+
+func TestSortColumn(t *testing.T) {
+	if _, ok := sortColumn(domain.QueryOptions{}); ok {
+		t.Error("an unrecognized SortBy must not produce a column")
+	}
+
+	// SortRandom has no datastore-level translation: see sortColumn's doc comment.
+	if _, ok := sortColumn(domain.QueryOptions{SortBy: domain.SortRandom}); ok {
+		t.Error("SortRandom must fall back to default order, not produce a column")
+	}
+
+	col, ok := sortColumn(domain.QueryOptions{SortBy: domain.SortByYear})
+	if !ok || col != "Year" {
+		t.Errorf("sortColumn(SortByYear) = %q, %v, want %q, true", col, ok, "Year")
+	}
+
+	col, ok = sortColumn(domain.QueryOptions{SortBy: domain.SortByYear, Desc: true})
+	if !ok || col != "-Year" {
+		t.Errorf("sortColumn(SortByYear, Desc) = %q, %v, want %q, true", col, ok, "-Year")
+	}
+}
+
+func TestRandomPage(t *testing.T) {
+	albums := make([]domain.Album, 5)
+	for i := range albums {
+		albums[i] = domain.Album{Id: fmt.Sprintf("a%d", i)}
+	}
+
+	page := randomPage(albums, 0, 3)
+	if len(page) != 3 {
+		t.Fatalf("got %d albums, want 3", len(page))
+	}
+	seen := map[string]bool{}
+	for _, al := range page {
+		seen[al.Id] = true
+	}
+	if len(seen) != 3 {
+		t.Error("randomPage returned a duplicate album")
+	}
+
+	if page := randomPage(albums, 10, 3); len(page) != 0 {
+		t.Errorf("an offset past the end must return no albums, got %d", len(page))
+	}
+
+	if page := randomPage(albums, 2, 0); len(page) != 3 {
+		t.Errorf("size <= 0 must return every album from offset onward, got %d", len(page))
+	}
+}
@@ -0,0 +1,252 @@
+package scanner
+
+import (
+	"crypto/md5"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/deluan/gosonic/consts"
+	"github.com/deluan/gosonic/domain"
+	"github.com/deluan/gosonic/engine"
+)
+
+// Scanner walks the media folders, turning the tags it reads off disk into domain.Artist,
+// domain.Album and domain.MediaFile records, and publishes an Events change for each one it
+// actually adds or modifies - re-scanning an unchanged file publishes nothing, so a routine
+// rescan of an untouched library doesn't flood subscribers the way a wall-clock timer would.
+// The scanner, not a caller's read-time "is this stale?" check, is where library changes
+// actually happen, so it's the only thing that should ever call events.Publish.
+type Scanner struct {
+	propRepo   domain.PropertyRepository
+	artistRepo domain.ArtistRepository
+	albumRepo  domain.AlbumRepository
+	mfileRepo  domain.MediaFileRepository
+	events     engine.Events
+}
+
+func NewScanner(pr domain.PropertyRepository, ar domain.ArtistRepository, alr domain.AlbumRepository,
+	mr domain.MediaFileRepository, ev engine.Events) *Scanner {
+	return &Scanner{pr, ar, alr, mr, ev}
+}
+
+// ScanTrack maps the tags read from path into a domain.MediaFile, resolves the artists it
+// references, aggregates it into its album, and persists all three, publishing a
+// TopicTrackAdded/TopicTrackUpdated event (and the analogous album event) only for whichever
+// of them actually changed. It returns the track record that was written.
+func (s *Scanner) ScanTrack(path string, tags RawTags) (domain.MediaFile, error) {
+	artists := s.resolveArtists(tags.Artists)
+	albumArtists := artists
+	if len(tags.AlbumArtists) > 0 {
+		albumArtists = s.resolveArtists(tags.AlbumArtists)
+	}
+
+	id := trackId(path)
+	previous, getErr := s.mfileRepo.Get(id)
+	existed := getErr == nil
+
+	album, err := s.scanAlbum(tags, albumArtists, previous)
+	if err != nil {
+		return domain.MediaFile{}, err
+	}
+
+	mf := domain.MediaFile{
+		Id:                 id,
+		Title:              tags.Title,
+		Album:              tags.Album,
+		AlbumId:            album.Id,
+		Artist:             joinNames(artists),
+		ArtistId:           firstId(artists),
+		Artists:            artists,
+		AlbumArtists:       albumArtists,
+		Genre:              firstOrEmpty(tags.Genres),
+		Genres:             tags.Genres,
+		MusicBrainzTrackId: tags.MusicBrainzTrackId,
+		DiscNumber:         tags.DiscNumber,
+		Comment:            tags.Comment,
+		Bpm:                tags.Bpm,
+		RGTrackGain:        tags.RGTrackGain,
+		RGTrackPeak:        tags.RGTrackPeak,
+		RGAlbumGain:        tags.RGAlbumGain,
+		RGAlbumPeak:        tags.RGAlbumPeak,
+		Year:               tags.Year,
+		TrackNumber:        tags.TrackNumber,
+		Duration:           tags.Duration,
+		Size:               tags.Size,
+		Suffix:             tags.Suffix,
+		BitRate:            tags.BitRate,
+	}
+	if previous != nil {
+		mf.Starred = previous.Starred
+		mf.HasCoverArt = previous.HasCoverArt
+		mf.CreatedAt = previous.CreatedAt
+	}
+
+	if err := s.mfileRepo.Put(&mf); err != nil {
+		return domain.MediaFile{}, fmt.Errorf("Error saving track %s: %v", path, err)
+	}
+	if !existed || mediaFileChanged(previous, &mf) {
+		s.publish(trackTopic(existed), mf.Id)
+	}
+	return mf, nil
+}
+
+// scanAlbum merges tags into the album's existing record, if any, so fields the scanner
+// doesn't own (Starred, PlayCount, PlayDate, Rating) survive a rescan instead of being reset
+// to zero, and accumulates SongCount/Duration rather than recomputing them from scratch, since
+// neither is derivable from a single track's tags alone. previousTrack is that track's prior
+// record, if any, so a re-scanned track that stays in this album updates the aggregate instead
+// of double-counting itself. A track that moved here from another album is counted as new in
+// this album; the previous album's aggregate is left as it was.
+func (s *Scanner) scanAlbum(tags RawTags, albumArtists []domain.ArtistRef, previousTrack *domain.MediaFile) (domain.Album, error) {
+	id := albumId(tags.Album, albumArtists)
+
+	album := domain.Album{
+		Id:           id,
+		Name:         tags.Album,
+		ArtistId:     firstId(albumArtists),
+		AlbumArtist:  joinNames(albumArtists),
+		AlbumArtists: albumArtists,
+		Genre:        firstOrEmpty(tags.Genres),
+		Genres:       tags.Genres,
+		Year:         tags.Year,
+	}
+
+	existing, getErr := s.albumRepo.Get(id)
+	existed := getErr == nil
+	if existed {
+		album.SongCount = existing.SongCount
+		album.Duration = existing.Duration
+		album.Starred = existing.Starred
+		album.PlayCount = existing.PlayCount
+		album.PlayDate = existing.PlayDate
+		album.Rating = existing.Rating
+		album.CreatedAt = existing.CreatedAt
+	}
+
+	songCountDelta, durationDelta := 1, tags.Duration
+	if previousTrack != nil && previousTrack.AlbumId == id {
+		songCountDelta, durationDelta = 0, tags.Duration-previousTrack.Duration
+	}
+	album.SongCount += songCountDelta
+	album.Duration += durationDelta
+
+	if err := s.albumRepo.Put(&album); err != nil {
+		return domain.Album{}, fmt.Errorf("Error saving album %s: %v", tags.Album, err)
+	}
+	if !existed || albumChanged(existing, &album) {
+		s.publish(albumTopic(existed), album.Id)
+	}
+	return album, nil
+}
+
+// resolveArtists turns the artist names read from tags into ArtistRef entries, creating an
+// Artist record (and publishing TopicArtistAdded) for any name not already known.
+func (s *Scanner) resolveArtists(names []string) []domain.ArtistRef {
+	refs := make([]domain.ArtistRef, len(names))
+	for i, name := range names {
+		id := artistId(name)
+		if _, err := s.artistRepo.Get(id); err != nil {
+			s.artistRepo.Put(&domain.Artist{Id: id, Name: name})
+			s.publish(engine.TopicArtistAdded, id)
+		}
+		refs[i] = domain.ArtistRef{Id: id, Name: name}
+	}
+	return refs
+}
+
+// Finish is called once a scan has processed every file. It records when the scan happened,
+// so Browser.Indexes/ArtistsIndex's ifModifiedSince check picks it up, and publishes a single
+// TopicDirectoryInvalidated for the whole scan, regardless of how many clients are polling.
+func (s *Scanner) Finish() error {
+	now := time.Now()
+	if err := s.propRepo.Put(consts.LastScan, strconv.FormatInt(now.UnixNano()/int64(time.Millisecond), 10)); err != nil {
+		return fmt.Errorf("Error saving LastScan property: %v", err)
+	}
+	s.publish(engine.TopicDirectoryInvalidated, "")
+	return nil
+}
+
+func (s *Scanner) publish(topic engine.Topic, id string) {
+	s.events.Publish(engine.Event{Topic: topic, Id: id, Timestamp: time.Now()})
+}
+
+func trackTopic(existed bool) engine.Topic {
+	if existed {
+		return engine.TopicTrackUpdated
+	}
+	return engine.TopicTrackAdded
+}
+
+func albumTopic(existed bool) engine.Topic {
+	if existed {
+		return engine.TopicAlbumUpdated
+	}
+	return engine.TopicAlbumAdded
+}
+
+// mediaFileChanged reports whether new differs from old in any field the scanner derives from
+// tags, ignoring fields it merely carries over from the previous record (Starred, HasCoverArt,
+// CreatedAt/UpdatedAt).
+func mediaFileChanged(old, new *domain.MediaFile) bool {
+	a, b := *old, *new
+	a.Starred, b.Starred = false, false
+	a.HasCoverArt, b.HasCoverArt = false, false
+	a.CreatedAt, b.CreatedAt = time.Time{}, time.Time{}
+	a.UpdatedAt, b.UpdatedAt = time.Time{}, time.Time{}
+	return !reflect.DeepEqual(a, b)
+}
+
+// albumChanged reports whether new differs from old in any field the scanner derives from
+// tags or aggregates from tracks, ignoring fields it merely carries over from the previous
+// record (Starred, PlayCount, PlayDate, Rating, CreatedAt/UpdatedAt).
+func albumChanged(old, new *domain.Album) bool {
+	a, b := *old, *new
+	a.Starred, b.Starred = false, false
+	a.PlayCount, b.PlayCount = 0, 0
+	a.PlayDate, b.PlayDate = time.Time{}, time.Time{}
+	a.Rating, b.Rating = 0, 0
+	a.CreatedAt, b.CreatedAt = time.Time{}, time.Time{}
+	a.UpdatedAt, b.UpdatedAt = time.Time{}, time.Time{}
+	return !reflect.DeepEqual(a, b)
+}
+
+func artistId(name string) string {
+	return hashId("artist", name)
+}
+
+func albumId(name string, albumArtists []domain.ArtistRef) string {
+	return hashId("album", firstId(albumArtists), name)
+}
+
+func trackId(path string) string {
+	return hashId("track", path)
+}
+
+func hashId(parts ...string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(strings.Join(parts, "\x00"))))
+}
+
+func joinNames(refs []domain.ArtistRef) string {
+	names := make([]string, len(refs))
+	for i, r := range refs {
+		names[i] = r.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func firstId(refs []domain.ArtistRef) string {
+	if len(refs) == 0 {
+		return ""
+	}
+	return refs[0].Id
+}
+
+func firstOrEmpty(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
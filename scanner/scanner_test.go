@@ -0,0 +1,235 @@
+package scanner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/deluan/gosonic/domain"
+	"github.com/deluan/gosonic/engine"
+)
+
+var errNotFound = errors.New("not found")
+
+type fakeArtistRepo struct{ artists map[string]*domain.Artist }
+
+func (f *fakeArtistRepo) Get(id string) (*domain.Artist, error) {
+	if a, ok := f.artists[id]; ok {
+		return a, nil
+	}
+	return nil, errNotFound
+}
+func (f *fakeArtistRepo) Exists(id string) (bool, error) { _, ok := f.artists[id]; return ok, nil }
+func (f *fakeArtistRepo) Put(a *domain.Artist) error {
+	if f.artists == nil {
+		f.artists = map[string]*domain.Artist{}
+	}
+	f.artists[a.Id] = a
+	return nil
+}
+
+type fakeAlbumRepo struct{ albums map[string]*domain.Album }
+
+func (f *fakeAlbumRepo) Get(id string) (*domain.Album, error) {
+	if a, ok := f.albums[id]; ok {
+		cp := *a
+		return &cp, nil
+	}
+	return nil, errNotFound
+}
+func (f *fakeAlbumRepo) Exists(id string) (bool, error) { _, ok := f.albums[id]; return ok, nil }
+func (f *fakeAlbumRepo) Put(a *domain.Album) error {
+	if f.albums == nil {
+		f.albums = map[string]*domain.Album{}
+	}
+	cp := *a
+	f.albums[a.Id] = &cp
+	return nil
+}
+func (f *fakeAlbumRepo) FindByArtist(artistId string, qo domain.QueryOptions) ([]domain.Album, int, error) {
+	return nil, 0, nil
+}
+func (f *fakeAlbumRepo) FindNewest(o domain.ListOptions) ([]domain.Album, error)           { return nil, nil }
+func (f *fakeAlbumRepo) FindRecentlyPlayed(o domain.ListOptions) ([]domain.Album, error)   { return nil, nil }
+func (f *fakeAlbumRepo) FindFrequentlyPlayed(o domain.ListOptions) ([]domain.Album, error) { return nil, nil }
+func (f *fakeAlbumRepo) FindTopRated(o domain.ListOptions) ([]domain.Album, error)         { return nil, nil }
+func (f *fakeAlbumRepo) FindStarred(o domain.ListOptions) ([]domain.Album, error)          { return nil, nil }
+func (f *fakeAlbumRepo) FindByYearRange(o domain.ListOptions) ([]domain.Album, error)      { return nil, nil }
+func (f *fakeAlbumRepo) FindByGenre(o domain.ListOptions) ([]domain.Album, error)          { return nil, nil }
+func (f *fakeAlbumRepo) FindRandom(o domain.ListOptions) ([]domain.Album, error)           { return nil, nil }
+
+type fakeMfileRepo struct{ files map[string]*domain.MediaFile }
+
+func (f *fakeMfileRepo) Get(id string) (*domain.MediaFile, error) {
+	if m, ok := f.files[id]; ok {
+		cp := *m
+		return &cp, nil
+	}
+	return nil, errNotFound
+}
+func (f *fakeMfileRepo) Put(mf *domain.MediaFile) error {
+	if f.files == nil {
+		f.files = map[string]*domain.MediaFile{}
+	}
+	cp := *mf
+	f.files[mf.Id] = &cp
+	return nil
+}
+func (f *fakeMfileRepo) FindByAlbum(albumId string, qo domain.QueryOptions) ([]domain.MediaFile, int, error) {
+	return nil, 0, nil
+}
+
+type fakePropRepo struct{ values map[string]string }
+
+func (f *fakePropRepo) Put(id, value string) error {
+	if f.values == nil {
+		f.values = map[string]string{}
+	}
+	f.values[id] = value
+	return nil
+}
+func (f *fakePropRepo) Get(id string) (string, error) { return f.values[id], nil }
+func (f *fakePropRepo) DefaultGet(id, defaultValue string) (string, error) {
+	if v, ok := f.values[id]; ok {
+		return v, nil
+	}
+	return defaultValue, nil
+}
+
+type fakeEvents struct{ published []engine.Event }
+
+func (f *fakeEvents) Subscribe(topic engine.Topic) <-chan engine.Event { return nil }
+func (f *fakeEvents) Publish(e engine.Event)                          { f.published = append(f.published, e) }
+
+func (f *fakeEvents) countTopic(topic engine.Topic) int {
+	n := 0
+	for _, e := range f.published {
+		if e.Topic == topic {
+			n++
+		}
+	}
+	return n
+}
+
+func newTestScanner() (*Scanner, *fakeAlbumRepo, *fakeMfileRepo, *fakeEvents) {
+	alr := &fakeAlbumRepo{}
+	mr := &fakeMfileRepo{}
+	ev := &fakeEvents{}
+	s := NewScanner(&fakePropRepo{}, &fakeArtistRepo{}, alr, mr, ev)
+	return s, alr, mr, ev
+}
+
+func TestHashIdIsStableAndDistinct(t *testing.T) {
+	if artistId("Artist A") != artistId("Artist A") {
+		t.Error("hashing the same name twice must produce the same id")
+	}
+	if artistId("Artist A") == artistId("Artist B") {
+		t.Error("hashing different names must produce different ids")
+	}
+	if artistId("Name") == trackId("Name") {
+		t.Error("ids must be namespaced by entity kind, not just the hashed value")
+	}
+}
+
+func TestJoinNames(t *testing.T) {
+	refs := []domain.ArtistRef{{Id: "1", Name: "A"}, {Id: "2", Name: "B"}}
+	if got := joinNames(refs); got != "A, B" {
+		t.Errorf("joinNames() = %q, want %q", got, "A, B")
+	}
+	if got := joinNames(nil); got != "" {
+		t.Errorf("joinNames(nil) = %q, want empty string", got)
+	}
+}
+
+func TestFirstId(t *testing.T) {
+	if got := firstId(nil); got != "" {
+		t.Errorf("firstId(nil) = %q, want empty string", got)
+	}
+	refs := []domain.ArtistRef{{Id: "1", Name: "A"}, {Id: "2", Name: "B"}}
+	if got := firstId(refs); got != "1" {
+		t.Errorf("firstId() = %q, want %q", got, "1")
+	}
+}
+
+func TestScanTrackAggregatesAlbumSongCountAndDuration(t *testing.T) {
+	s, alr, _, _ := newTestScanner()
+
+	tags1 := RawTags{Title: "Track 1", Album: "Album X", Artists: []string{"Artist A"}, Duration: 200}
+	tags2 := RawTags{Title: "Track 2", Album: "Album X", Artists: []string{"Artist A"}, Duration: 180}
+
+	mf1, err := s.ScanTrack("/a/1.mp3", tags1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ScanTrack("/a/2.mp3", tags2); err != nil {
+		t.Fatal(err)
+	}
+
+	al, err := alr.Get(mf1.AlbumId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if al.SongCount != 2 {
+		t.Errorf("SongCount after scanning 2 tracks = %d, want 2", al.SongCount)
+	}
+	if al.Duration != 380 {
+		t.Errorf("Duration after scanning 2 tracks (200+180) = %d, want 380", al.Duration)
+	}
+}
+
+func TestScanTrackRescanUnchangedDoesNotDoubleCountOrRepublish(t *testing.T) {
+	s, alr, _, ev := newTestScanner()
+
+	tags := RawTags{Title: "Track 1", Album: "Album X", Artists: []string{"Artist A"}, Duration: 200}
+	mf, err := s.ScanTrack("/a/1.mp3", tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addedTrack := ev.countTopic(engine.TopicTrackAdded)
+	addedAlbum := ev.countTopic(engine.TopicAlbumAdded)
+
+	if _, err := s.ScanTrack("/a/1.mp3", tags); err != nil {
+		t.Fatal(err)
+	}
+
+	al, err := alr.Get(mf.AlbumId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if al.SongCount != 1 {
+		t.Errorf("SongCount after rescanning the same unchanged track = %d, want 1", al.SongCount)
+	}
+	if al.Duration != 200 {
+		t.Errorf("Duration after rescanning the same unchanged track = %d, want 200", al.Duration)
+	}
+	if got := ev.countTopic(engine.TopicTrackAdded); got != addedTrack {
+		t.Errorf("TopicTrackAdded published again on an unchanged rescan: %d, want %d", got, addedTrack)
+	}
+	if got := ev.countTopic(engine.TopicTrackUpdated); got != 0 {
+		t.Errorf("TopicTrackUpdated published on an unchanged rescan: got %d, want 0", got)
+	}
+	if got := ev.countTopic(engine.TopicAlbumAdded); got != addedAlbum {
+		t.Errorf("TopicAlbumAdded published again on an unchanged rescan: %d, want %d", got, addedAlbum)
+	}
+	if got := ev.countTopic(engine.TopicAlbumUpdated); got != 0 {
+		t.Errorf("TopicAlbumUpdated published on an unchanged rescan: got %d, want 0", got)
+	}
+}
+
+func TestScanTrackPublishesUpdatedWhenTagsChange(t *testing.T) {
+	s, _, _, ev := newTestScanner()
+
+	tags := RawTags{Title: "Track 1", Album: "Album X", Artists: []string{"Artist A"}, Duration: 200}
+	if _, err := s.ScanTrack("/a/1.mp3", tags); err != nil {
+		t.Fatal(err)
+	}
+
+	retagged := tags
+	retagged.Title = "Track 1 (Remastered)"
+	if _, err := s.ScanTrack("/a/1.mp3", retagged); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ev.countTopic(engine.TopicTrackUpdated); got != 1 {
+		t.Errorf("TopicTrackUpdated after a real tag change: got %d, want 1", got)
+	}
+}
@@ -0,0 +1,29 @@
+package scanner
+
+// RawTags is what an audio tag reader extracts from a file, before it's turned into the
+// domain model. Subsonic clients want multi-valued tags (several artists, several genres)
+// and the identifiers/levels taggers write but single-valued Child/AlbumInfo/SongInfo fields
+// can't carry on their own, so Scanner.ScanTrack/scanAlbum fill in both representations.
+type RawTags struct {
+	Title        string
+	Album        string
+	Artists      []string
+	AlbumArtists []string
+	Genres       []string
+	Year         int
+	TrackNumber  int
+	DiscNumber   int
+	Duration     int
+	Size         string
+	Suffix       string
+	BitRate      int
+
+	MusicBrainzTrackId string
+	Comment            string
+	Bpm                int
+
+	RGTrackGain float32
+	RGTrackPeak float32
+	RGAlbumGain float32
+	RGAlbumPeak float32
+}